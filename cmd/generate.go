@@ -0,0 +1,140 @@
+package cmd
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/SRodi/hclwrite-example/generator"
+	"github.com/SRodi/hclwrite-example/secretsmanager"
+)
+
+var (
+	metafilePath     string
+	bytesValue       string
+	extractVariables bool
+)
+
+var generateCmd = &cobra.Command{
+	Use:   "generate",
+	Short: "Generate Terraform files",
+}
+
+var generateLocalsCmd = &cobra.Command{
+	Use:   "locals",
+	Short: "Generate locals.tf from Secrets Manager inventory",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runGenerateLocals()
+	},
+}
+
+var generateMainCmd = &cobra.Command{
+	Use:   "main",
+	Short: "Generate main.tf for the ingress secret resource",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runGenerateMain()
+	},
+}
+
+var generateAllCmd = &cobra.Command{
+	Use:   "all",
+	Short: "Generate both locals.tf and main.tf",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := runGenerateLocals(); err != nil {
+			return err
+		}
+		return runGenerateMain()
+	},
+}
+
+func init() {
+	generateMainCmd.Flags().StringVar(&metafilePath, "metafile", "", "path to an HCL metafile describing resources to generate; falls back to the built-in ingress-secret example when unset")
+	generateAllCmd.Flags().StringVar(&metafilePath, "metafile", "", "path to an HCL metafile describing resources to generate; falls back to the built-in ingress-secret example when unset")
+	generateLocalsCmd.Flags().StringVar(&bytesValue, "bytes-value", "", "base64-encoded secret value, used instead of reading from Secrets Manager")
+	generateAllCmd.Flags().StringVar(&bytesValue, "bytes-value", "", "base64-encoded secret value, used instead of reading from Secrets Manager")
+	generateLocalsCmd.Flags().BoolVar(&extractVariables, "extract-variables", false, "write secret values to variables.tf/terraform.tfvars.json instead of inlining them in locals.tf")
+	generateAllCmd.Flags().BoolVar(&extractVariables, "extract-variables", false, "write secret values to variables.tf/terraform.tfvars.json instead of inlining them in locals.tf")
+
+	generateCmd.AddCommand(generateLocalsCmd, generateMainCmd, generateAllCmd)
+}
+
+func runGenerateLocals() error {
+	secrets, err := fetchSecrets()
+	if err != nil {
+		return err
+	}
+	if extractVariables {
+		return generator.CreateLocalsWithVariables(outputDir, secrets)
+	}
+	generator.CreateLocals(outputDir, secrets)
+	return nil
+}
+
+func runGenerateMain() error {
+	resolvedCluster, resolvedNamespace, err := resolveClusterAndNamespace()
+	if err != nil {
+		return err
+	}
+	if metafilePath != "" {
+		return generator.RunMetafile(outputDir, metafilePath, resolvedCluster, resolvedNamespace)
+	}
+	generator.CreateMain(outputDir, resolvedCluster, resolvedNamespace)
+	return nil
+}
+
+// fetchSecrets returns the secret inventory to generate locals.tf from.
+// A literal value supplied via --bytes-value or --secrets-file takes
+// priority over calling out to Secrets Manager, so operators can pipe in
+// a single secret value without it ever landing in shell history or env
+// vars.
+func fetchSecrets() ([]secretsmanager.Secret, error) {
+	if value, ok, err := readInlineSecretValue(); err != nil {
+		return nil, err
+	} else if ok {
+		return []secretsmanager.Secret{{
+			Name:   "secret-0",
+			Fields: []secretsmanager.Field{{Name: "value", CRN: value}},
+		}}, nil
+	}
+
+	client, err := secretsmanager.NewClient(secretsmanager.Config{
+		DryRun:      dryRun,
+		InstanceURL: secretsManagerURL,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("creating secrets manager client: %w", err)
+	}
+	return client.ListSecrets()
+}
+
+func readInlineSecretValue() (string, bool, error) {
+	switch {
+	case bytesValue != "":
+		decoded, err := base64.StdEncoding.DecodeString(bytesValue)
+		if err != nil {
+			return "", false, fmt.Errorf("decoding --bytes-value: %w", err)
+		}
+		return string(decoded), true, nil
+
+	case secretsFile == "-":
+		data, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return "", false, fmt.Errorf("reading secret value from stdin: %w", err)
+		}
+		return strings.TrimSpace(string(data)), true, nil
+
+	case secretsFile != "":
+		data, err := os.ReadFile(secretsFile)
+		if err != nil {
+			return "", false, fmt.Errorf("reading --secrets-file: %w", err)
+		}
+		return strings.TrimSpace(string(data)), true, nil
+
+	default:
+		return "", false, nil
+	}
+}
@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// resolveClusterAndNamespace returns the --cluster-id and --namespace flag
+// values, prompting for whichever one is missing so operators aren't
+// forced to pass them on the command line. Both prompts share one scanner
+// over os.Stdin: a fresh bufio.Scanner per call can buffer the next
+// prompt's line out from under it, since Read isn't guaranteed to stop at
+// a line boundary.
+func resolveClusterAndNamespace() (cluster, ns string, err error) {
+	scanner := bufio.NewScanner(os.Stdin)
+
+	cluster = clusterID
+	if cluster == "" {
+		cluster, err = promptLine(scanner, "Cluster ID: ")
+		if err != nil {
+			return "", "", err
+		}
+	}
+
+	ns = namespace
+	if ns == "" {
+		ns, err = promptLine(scanner, "Namespace: ")
+		if err != nil {
+			return "", "", err
+		}
+	}
+
+	return cluster, ns, nil
+}
+
+func promptLine(scanner *bufio.Scanner, prompt string) (string, error) {
+	fmt.Fprint(os.Stderr, prompt)
+	if !scanner.Scan() {
+		// Scan returns false with a nil Err() on a clean EOF, which happens
+		// whenever stdin was already consumed (e.g. piped in as
+		// --secrets-file -). Treat that the same as a read error rather
+		// than silently returning an empty value: a blank cluster ID or
+		// namespace would otherwise land in the generated Terraform
+		// without any indication prompting never actually happened.
+		if err := scanner.Err(); err != nil {
+			return "", err
+		}
+		return "", fmt.Errorf("no input available for prompt %q: stdin already closed", prompt)
+	}
+	return strings.TrimSpace(scanner.Text()), nil
+}
@@ -0,0 +1,42 @@
+// Package cmd implements the hclwrite-example CLI: subcommands to generate
+// Terraform locals and resources for ibm_container_ingress_secret_opaque
+// from IBM Cloud Secrets Manager inventory.
+package cmd
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/SRodi/hclwrite-example/generator"
+)
+
+var (
+	outputDir         string
+	clusterID         string
+	namespace         string
+	secretsFile       string
+	dryRun            bool
+	secretsManagerURL string
+)
+
+var rootCmd = &cobra.Command{
+	Use:   "hclwrite-example",
+	Short: "Generate Terraform for IBM Cloud ingress secrets from Secrets Manager inventory",
+}
+
+func init() {
+	rootCmd.PersistentFlags().StringVar(&outputDir, "output-dir", generator.DefaultOutputDir, "directory to write generated .tf files to")
+	rootCmd.PersistentFlags().StringVar(&clusterID, "cluster-id", "", "target IKS cluster ID (prompted for interactively if omitted)")
+	rootCmd.PersistentFlags().StringVar(&namespace, "namespace", "", "target Kubernetes namespace (prompted for interactively if omitted)")
+	rootCmd.PersistentFlags().StringVar(&secretsFile, "secrets-file", "", "path to a file with a secret value to use instead of Secrets Manager; \"-\" reads from stdin")
+	rootCmd.PersistentFlags().BoolVar(&dryRun, "dry-run", false, "use synthetic secrets instead of calling IBM Cloud Secrets Manager")
+	rootCmd.PersistentFlags().StringVar(&secretsManagerURL, "secrets-manager-url", os.Getenv("SECRETS_MANAGER_URL"), "IBM Cloud Secrets Manager instance URL")
+
+	rootCmd.AddCommand(generateCmd)
+}
+
+// Execute runs the CLI, returning any error the chosen subcommand produced.
+func Execute() error {
+	return rootCmd.Execute()
+}
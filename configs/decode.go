@@ -0,0 +1,13 @@
+package configs
+
+import (
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/gohcl"
+)
+
+// decodeBody decodes body into target using gohcl, leaving expression-typed
+// fields (hcl.Expression) unevaluated for the caller to resolve later
+// against its own hcl.EvalContext.
+func decodeBody(body hcl.Body, target interface{}) hcl.Diagnostics {
+	return gohcl.DecodeBody(body, nil, target)
+}
@@ -0,0 +1,58 @@
+package configs
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// ExtractedVariable is an attribute that was promoted into a Terraform
+// variable reference rather than inlined, returned so the caller can
+// declare it (e.g. in variables.tf) and supply its real Value (e.g. via
+// tfvars).
+type ExtractedVariable struct {
+	Name  string
+	Value cty.Value
+}
+
+// EmitResource appends a "resource" block for resolved to body, writing its
+// for_each (when present) and attributes in Generate's declared order.
+// Attributes with Depends.Variable set are written as a reference to
+// var.<generate name>_<attribute name> instead of being inlined, and
+// returned as ExtractedVariables.
+func EmitResource(body *hclwrite.Body, resolved *Resolved) ([]ExtractedVariable, error) {
+	if len(resolved.Labels) == 0 {
+		return nil, fmt.Errorf("generate %q: labels must supply a resource type and name", resolved.Name)
+	}
+
+	block := body.AppendNewBlock("resource", resolved.Labels)
+	blockBody := block.Body()
+
+	if resolved.ForEach.IsTraversal {
+		blockBody.SetAttributeTraversal("for_each", resolved.ForEach.Traversal)
+	} else if resolved.ForEach.Value.IsKnown() {
+		blockBody.SetAttributeValue("for_each", resolved.ForEach.Value)
+	}
+
+	var extracted []ExtractedVariable
+	for _, attr := range resolved.Attributes {
+		if attr.Variable {
+			varName := resolved.Name + "_" + attr.Name
+			blockBody.SetAttributeTraversal(attr.Name, hcl.Traversal{
+				hcl.TraverseRoot{Name: "var"},
+				hcl.TraverseAttr{Name: varName},
+			})
+			extracted = append(extracted, ExtractedVariable{Name: varName, Value: attr.Value})
+			continue
+		}
+		if attr.IsTraversal {
+			blockBody.SetAttributeTraversal(attr.Name, attr.Traversal)
+			continue
+		}
+		blockBody.SetAttributeValue(attr.Name, attr.Value)
+	}
+
+	return extracted, nil
+}
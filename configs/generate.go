@@ -0,0 +1,26 @@
+package configs
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/hcl/v2"
+)
+
+// Walk resolves every Generate in mf against ctx and calls emit for each one
+// whose "when" expression is true (or absent). Generates are visited in
+// metafile order.
+func (mf *Metafile) Walk(ctx *hcl.EvalContext, emit func(*Resolved) error) error {
+	for _, g := range mf.Generates {
+		resolved, ok, err := g.Resolve(ctx)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			continue
+		}
+		if err := emit(resolved); err != nil {
+			return fmt.Errorf("generate %q: %w", g.Name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+// Package configs reads a user-supplied HCL "metafile" describing which
+// Terraform resources to generate, then walks it to emit the corresponding
+// .tf files via hclwrite. It lets callers add new generated resource types
+// without recompiling the generator.
+package configs
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclparse"
+)
+
+// Metafile is the top-level shape of a metafile: zero or more "generate"
+// blocks, each describing one resource to emit.
+type Metafile struct {
+	Generates []*Generate `hcl:"generate,block"`
+}
+
+// Generate describes a single resource to emit. Name is the block label
+// used for diagnostics; the resource type and name that end up in the
+// generated Terraform come from Labels.
+//
+//	generate "ingress_secret" {
+//	  when     = true
+//	  for_each = local.secrets
+//	  labels   = ["ibm_container_ingress_secret_opaque", "ingress-secret"]
+//
+//	  attribute "cluster" {
+//	    value = env.CLUSTER_ID
+//	  }
+//
+//	  attribute "secret_namespace" {
+//	    value = env.NAMESPACE
+//
+//	    depends {
+//	      variable = true
+//	    }
+//	  }
+//	}
+type Generate struct {
+	Name string `hcl:"name,label"`
+
+	// When gates whether this Generate is emitted at all. Evaluated against
+	// the caller's hcl.EvalContext; omitted means "always emit".
+	When hcl.Expression `hcl:"when,optional"`
+
+	// ForEach is usually a reference (e.g. local.secrets) rather than a
+	// value the generator can evaluate itself, so it is carried through to
+	// the emitted resource's for_each argument.
+	ForEach hcl.Expression `hcl:"for_each,optional"`
+
+	// Labels supplies the resource type and name, e.g.
+	// ["ibm_container_ingress_secret_opaque", "ingress-secret"].
+	Labels hcl.Expression `hcl:"labels,optional"`
+
+	// Attributes is one block per attribute to set on the generated
+	// resource. Each is evaluated against the EvalContext when possible
+	// and otherwise carried through as a traversal (see Resolve).
+	Attributes []*AttributeSpec `hcl:"attribute,block"`
+}
+
+// AttributeSpec is one "attribute" block inside a Generate: the attribute
+// name, its value expression, and an optional Depends toggle.
+type AttributeSpec struct {
+	Name  string         `hcl:"name,label"`
+	Value hcl.Expression `hcl:"value,attr"`
+
+	// Depends marks attributes that need special handling beyond plain
+	// inlining, e.g. Variable: true to promote the value into a Terraform
+	// variable instead of inlining it.
+	Depends *Depends `hcl:"depends,block"`
+}
+
+// Depends toggles how an attribute's value is emitted.
+type Depends struct {
+	// Variable promotes the attribute's value into a Terraform variable
+	// (declared sensitive, with the real value written to tfvars) instead
+	// of inlining it in the generated resource.
+	Variable bool `hcl:"variable,optional"`
+}
+
+// Load parses the metafile at path and decodes it into a Metafile.
+func Load(path string) (*Metafile, error) {
+	parser := hclparse.NewParser()
+	file, diags := parser.ParseHCLFile(path)
+	if diags.HasErrors() {
+		return nil, fmt.Errorf("configs: parsing metafile %q: %w", path, diags)
+	}
+
+	var mf Metafile
+	if diags := decodeBody(file.Body, &mf); diags.HasErrors() {
+		return nil, fmt.Errorf("configs: decoding metafile %q: %w", path, diags)
+	}
+	return &mf, nil
+}
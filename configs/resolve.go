@@ -0,0 +1,135 @@
+package configs
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
+	"github.com/zclconf/go-cty/cty/gocty"
+)
+
+// Attribute is a single resolved attribute value: either a literal cty
+// value, or a traversal for expressions (like each.key or local.secrets)
+// that reference symbols only meaningful inside the generated Terraform,
+// not the generator's own EvalContext.
+type Attribute struct {
+	Name        string
+	Value       cty.Value
+	Traversal   hcl.Traversal
+	IsTraversal bool
+
+	// Variable marks an attribute whose value must be promoted into a
+	// Terraform variable (see AttributeSpec.Depends) instead of being
+	// inlined into the generated resource.
+	Variable bool
+}
+
+// Resolved is a Generate with its "when" gate decided and its for_each,
+// labels and attributes evaluated against an hcl.EvalContext.
+type Resolved struct {
+	Name       string
+	ForEach    Attribute
+	Labels     []string
+	Attributes []Attribute
+}
+
+// Resolve evaluates g against ctx, returning nil (with ok=false) when the
+// Generate's "when" expression evaluates to false.
+func (g *Generate) Resolve(ctx *hcl.EvalContext) (resolved *Resolved, ok bool, err error) {
+	enabled := true
+	if g.When != nil {
+		val, diags := g.When.Value(ctx)
+		if diags.HasErrors() {
+			return nil, false, fmt.Errorf("generate %q: evaluating when: %w", g.Name, diags)
+		}
+		if val.Type() != cty.Bool {
+			return nil, false, fmt.Errorf("generate %q: when must be a bool", g.Name)
+		}
+		enabled = val.True()
+	}
+	if !enabled {
+		return nil, false, nil
+	}
+
+	labels, err := g.resolveLabels(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+
+	var forEach Attribute
+	if g.ForEach != nil {
+		forEach, err = resolveAttribute("for_each", g.ForEach, ctx)
+		if err != nil {
+			return nil, false, fmt.Errorf("generate %q: %w", g.Name, err)
+		}
+	}
+
+	attrs, err := g.resolveAttributes(ctx)
+	if err != nil {
+		return nil, false, fmt.Errorf("generate %q: %w", g.Name, err)
+	}
+
+	return &Resolved{
+		Name:       g.Name,
+		ForEach:    forEach,
+		Labels:     labels,
+		Attributes: attrs,
+	}, true, nil
+}
+
+func (g *Generate) resolveLabels(ctx *hcl.EvalContext) ([]string, error) {
+	if g.Labels == nil {
+		return nil, nil
+	}
+	val, diags := g.Labels.Value(ctx)
+	if diags.HasErrors() {
+		return nil, fmt.Errorf("generate %q: evaluating labels: %w", g.Name, diags)
+	}
+	// A bracket literal like ["a", "b"] evaluates to cty.Tuple, not
+	// cty.List, so it needs converting before gocty can decode it into a
+	// []string.
+	listVal, err := convert.Convert(val, cty.List(cty.String))
+	if err != nil {
+		return nil, fmt.Errorf("generate %q: labels must be a list of strings: %w", g.Name, err)
+	}
+	var labels []string
+	if err := gocty.FromCtyValue(listVal, &labels); err != nil {
+		return nil, fmt.Errorf("generate %q: labels must be a list of strings: %w", g.Name, err)
+	}
+	return labels, nil
+}
+
+func (g *Generate) resolveAttributes(ctx *hcl.EvalContext) ([]Attribute, error) {
+	attrs := make([]Attribute, 0, len(g.Attributes))
+	for _, spec := range g.Attributes {
+		attr, err := resolveAttribute(spec.Name, spec.Value, ctx)
+		if err != nil {
+			return nil, err
+		}
+		if spec.Depends != nil {
+			attr.Variable = spec.Depends.Variable
+		}
+		attrs = append(attrs, attr)
+	}
+	return attrs, nil
+}
+
+// resolveAttribute evaluates expr against ctx. When expr is a bare
+// traversal whose root isn't defined in ctx (e.g. each.key or
+// local.secrets, which only exist in the generated Terraform, not here),
+// the traversal is carried through unevaluated instead of erroring.
+func resolveAttribute(name string, expr hcl.Expression, ctx *hcl.EvalContext) (Attribute, error) {
+	if trav, diags := hcl.AbsTraversalForExpr(expr); !diags.HasErrors() {
+		root := trav.RootName()
+		if _, defined := ctx.Variables[root]; !defined {
+			return Attribute{Name: name, Traversal: trav, IsTraversal: true}, nil
+		}
+	}
+
+	val, diags := expr.Value(ctx)
+	if diags.HasErrors() {
+		return Attribute{}, fmt.Errorf("evaluating attribute %q: %w", name, diags)
+	}
+	return Attribute{Name: name, Value: val}, nil
+}
@@ -0,0 +1,196 @@
+// Package generator writes the Terraform files for the
+// ibm_container_ingress_secret_opaque example: a locals.tf built from
+// Secrets Manager inventory, and a main.tf for the resource itself, either
+// hard-coded or driven by a configs.Metafile.
+package generator
+
+import (
+	"os"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/SRodi/hclwrite-example/configs"
+	"github.com/SRodi/hclwrite-example/hclx"
+	"github.com/SRodi/hclwrite-example/secretsmanager"
+)
+
+// DefaultOutputDir is used when the caller doesn't override the output
+// directory.
+const DefaultOutputDir = "terraform"
+
+const (
+	localsFileName = "locals.tf"
+	mainFileName   = "main.tf"
+)
+
+func makeDir(path string) {
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		os.Mkdir(path, os.ModeDir|0755)
+	} else if err != nil {
+		panic(err)
+	}
+}
+
+func createFile(dir, fileName string) *os.File {
+	file, err := os.Create(dir + "/" + fileName)
+	if err != nil {
+		panic(err)
+	}
+	return file
+}
+
+// CreateLocals writes dir/locals.tf, building a "secrets" local from
+// secrets.
+func CreateLocals(dir string, secrets []secretsmanager.Secret) {
+	hclFile := hclwrite.NewEmptyFile()
+	makeDir(dir)
+	tfFile := createFile(dir, localsFileName)
+
+	terraformLocals := hclFile.Body().AppendNewBlock("locals", nil)
+
+	secretsMap := make(map[string]cty.Value)
+	for _, secret := range secrets {
+		crnMap := make(map[string]cty.Value)
+		for _, field := range secret.Fields {
+			crnMap[field.Name] = cty.StringVal(field.CRN)
+		}
+		secretsMap[secret.Name] = cty.ObjectVal(map[string]cty.Value{
+			"fields": cty.ObjectVal(crnMap),
+		})
+	}
+
+	terraformLocals.Body().SetAttributeValue("secrets", cty.ObjectVal(secretsMap))
+	tfFile.Write(hclFile.Bytes())
+}
+
+// CreateLocalsWithVariables writes dir/locals.tf like CreateLocals, except
+// each secret's fields are kept out of the committed file: they're written
+// to dir/terraform.tfvars.json instead, declared in dir/variables.tf as a
+// sensitive variable "secret_<name>", and referenced from the "secrets"
+// local as var.secret_<name>.
+func CreateLocalsWithVariables(dir string, secrets []secretsmanager.Secret) error {
+	hclFile := hclwrite.NewEmptyFile()
+	makeDir(dir)
+	tfFile := createFile(dir, localsFileName)
+
+	vars := NewVariableValues()
+	keys := make([]string, 0, len(secrets))
+	refs := make([]hclx.Traversal, 0, len(secrets))
+	for _, secret := range secrets {
+		varName := "secret_" + sanitizeIdent(secret.Name)
+
+		crnMap := make(map[string]cty.Value)
+		for _, field := range secret.Fields {
+			crnMap[field.Name] = cty.StringVal(field.CRN)
+		}
+		vars.Add(varName, cty.ObjectVal(map[string]cty.Value{
+			"fields": cty.ObjectVal(crnMap),
+		}))
+
+		keys = append(keys, secret.Name)
+		refs = append(refs, hclx.Traversal{hclx.Root{Name: "var"}, hclx.Attr{Name: varName}})
+	}
+
+	terraformLocals := hclFile.Body().AppendNewBlock("locals", nil)
+	terraformLocals.Body().SetAttributeRaw("secrets", objectOfTraversals(keys, refs))
+	tfFile.Write(hclFile.Bytes())
+
+	return vars.Write(dir)
+}
+
+// CreateMain writes dir/main.tf: the hard-coded
+// ibm_container_ingress_secret_opaque resource that reads its fields from
+// the "secrets" local.
+func CreateMain(dir, clusterID, namespace string) {
+	hclFile := hclwrite.NewEmptyFile()
+	makeDir(dir)
+	tfFile := createFile(dir, mainFileName)
+
+	resource := hclFile.Body().AppendNewBlock("resource", []string{"ibm_container_ingress_secret_opaque", "ingress-secret"})
+	resourceBody := resource.Body()
+
+	resourceBody.SetAttributeTraversal("for_each", hcl.Traversal{
+		hcl.TraverseRoot{Name: "local"},
+		hcl.TraverseAttr{Name: "secrets"},
+	})
+	resourceBody.SetAttributeValue("cluster", cty.StringVal(clusterID))
+	resourceBody.SetAttributeTraversal("secret_name", hcl.Traversal{
+		hcl.TraverseRoot{Name: "each"},
+		hcl.TraverseAttr{Name: "key"},
+	})
+	resourceBody.SetAttributeValue("secret_namespace", cty.StringVal(namespace))
+
+	dynamicBlock := resourceBody.AppendNewBlock("dynamic", []string{"fields"})
+	dynamicBlockBody := dynamicBlock.Body()
+
+	fieldsTraversal, err := hclx.ParseTraversalStr("local.secrets[each.key].fields")
+	if err != nil {
+		panic(err)
+	}
+	hclx.SetAttributeTraversal(dynamicBlockBody, "for_each", fieldsTraversal)
+
+	contentBlock := dynamicBlockBody.AppendNewBlock("content", nil)
+	contentBlockBody := contentBlock.Body()
+
+	contentBlockBody.SetAttributeTraversal("field_name", hcl.Traversal{
+		hcl.TraverseRoot{Name: "fields"},
+		hcl.TraverseAttr{Name: "key"},
+	})
+	contentBlockBody.SetAttributeTraversal("crn", hcl.Traversal{
+		hcl.TraverseRoot{Name: "fields"},
+		hcl.TraverseAttr{Name: "value"},
+	})
+
+	tfFile.Write(hclFile.Bytes())
+}
+
+// EvalContext returns the EvalContext used to resolve metafile expressions.
+// Deliberately omits "local" and "each": those only exist in the generated
+// Terraform, so leaving them undefined here makes configs.Generate.Resolve
+// carry references to them through as traversals instead of trying (and
+// failing) to evaluate them.
+func EvalContext(clusterID, namespace string) *hcl.EvalContext {
+	return &hcl.EvalContext{
+		Variables: map[string]cty.Value{
+			"env": cty.ObjectVal(map[string]cty.Value{
+				"CLUSTER_ID": cty.StringVal(clusterID),
+				"NAMESPACE":  cty.StringVal(namespace),
+			}),
+		},
+	}
+}
+
+// RunMetafile writes dir/main.tf by reading the metafile at metafilePath
+// and emitting a resource block for every enabled generate block it
+// declares. Attributes marked Depends{Variable: true} are written to
+// dir/variables.tf and dir/terraform.tfvars.json instead of being inlined.
+func RunMetafile(dir, metafilePath, clusterID, namespace string) error {
+	mf, err := configs.Load(metafilePath)
+	if err != nil {
+		return err
+	}
+
+	hclFile := hclwrite.NewEmptyFile()
+	makeDir(dir)
+	tfFile := createFile(dir, mainFileName)
+
+	vars := NewVariableValues()
+	err = mf.Walk(EvalContext(clusterID, namespace), func(resolved *configs.Resolved) error {
+		extracted, err := configs.EmitResource(hclFile.Body(), resolved)
+		if err != nil {
+			return err
+		}
+		for _, ev := range extracted {
+			vars.Add(ev.Name, ev.Value)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	tfFile.Write(hclFile.Bytes())
+	return vars.Write(dir)
+}
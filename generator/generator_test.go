@@ -0,0 +1,123 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+
+	"github.com/SRodi/hclwrite-example/secretsmanager"
+)
+
+func fixtureSecrets() []secretsmanager.Secret {
+	return []secretsmanager.Secret{
+		{
+			Name: "secret-0",
+			Fields: []secretsmanager.Field{
+				{Name: "field-0", CRN: "crn:v1:bluemix:aaa"},
+				{Name: "field-1", CRN: "crn:v1:bluemix:bbb"},
+			},
+		},
+		{
+			Name: "secret-1",
+			Fields: []secretsmanager.Field{
+				{Name: "field-0", CRN: "crn:v1:bluemix:ccc"},
+			},
+		},
+	}
+}
+
+func readGolden(t *testing.T, name string) string {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatalf("reading golden file %s: %v", name, err)
+	}
+	return string(data)
+}
+
+func TestCreateLocals(t *testing.T) {
+	dir := t.TempDir()
+
+	CreateLocals(dir, fixtureSecrets())
+
+	got, err := os.ReadFile(filepath.Join(dir, localsFileName))
+	if err != nil {
+		t.Fatalf("reading generated locals.tf: %v", err)
+	}
+
+	want := readGolden(t, "locals.tf.golden")
+	if diff := cmp.Diff(want, string(got)); diff != "" {
+		t.Errorf("locals.tf mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestCreateMain(t *testing.T) {
+	dir := t.TempDir()
+
+	CreateMain(dir, "test-cluster", "test-ns")
+
+	got, err := os.ReadFile(filepath.Join(dir, mainFileName))
+	if err != nil {
+		t.Fatalf("reading generated main.tf: %v", err)
+	}
+
+	want := readGolden(t, "main.tf.golden")
+	if diff := cmp.Diff(want, string(got)); diff != "" {
+		t.Errorf("main.tf mismatch (-want +got):\n%s", diff)
+	}
+
+	assertForEachResolvesToLocalSecrets(t, got)
+}
+
+// assertForEachResolvesToLocalSecrets parses the generated main.tf back
+// through hclsyntax and checks that the resource's for_each is the
+// local.secrets traversal, catching regressions in token construction that
+// a byte-level golden diff alone might miss.
+func assertForEachResolvesToLocalSecrets(t *testing.T, src []byte) {
+	t.Helper()
+
+	f, diags := hclsyntax.ParseConfig(src, "main.tf", hcl.InitialPos)
+	if diags.HasErrors() {
+		t.Fatalf("parsing generated main.tf: %v", diags)
+	}
+
+	body, ok := f.Body.(*hclsyntax.Body)
+	if !ok || len(body.Blocks) == 0 {
+		t.Fatalf("expected at least one block in generated main.tf")
+	}
+
+	attr, ok := body.Blocks[0].Body.Attributes["for_each"]
+	if !ok {
+		t.Fatalf("resource block has no for_each attribute")
+	}
+
+	got, diags := hcl.AbsTraversalForExpr(attr.Expr)
+	if diags.HasErrors() {
+		t.Fatalf("for_each is not a traversal: %v", diags)
+	}
+
+	want := hcl.Traversal{
+		hcl.TraverseRoot{Name: "local"},
+		hcl.TraverseAttr{Name: "secrets"},
+	}
+	if diff := cmp.Diff(traversalNames(want), traversalNames(got)); diff != "" {
+		t.Errorf("for_each traversal mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func traversalNames(t hcl.Traversal) []string {
+	names := make([]string, 0, len(t))
+	for _, step := range t {
+		switch s := step.(type) {
+		case hcl.TraverseRoot:
+			names = append(names, s.Name)
+		case hcl.TraverseAttr:
+			names = append(names, s.Name)
+		}
+	}
+	return names
+}
@@ -0,0 +1,29 @@
+package generator
+
+import (
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+
+	"github.com/SRodi/hclwrite-example/hclx"
+)
+
+// objectOfTraversals renders an HCL object constructor whose values are
+// traversals (e.g. { secret-0 = var.secret_secret_0 }). hclwrite's
+// SetAttributeValue only accepts literal cty values, so an object with
+// traversal-valued entries has to be built as raw tokens instead.
+func objectOfTraversals(keys []string, values []hclx.Traversal) hclwrite.Tokens {
+	toks := hclwrite.Tokens{
+		{Type: hclsyntax.TokenOBrace, Bytes: []byte("{")},
+		{Type: hclsyntax.TokenNewline, Bytes: []byte("\n")},
+	}
+	for i, key := range keys {
+		toks = append(toks,
+			&hclwrite.Token{Type: hclsyntax.TokenIdent, Bytes: []byte(key)},
+			&hclwrite.Token{Type: hclsyntax.TokenEqual, Bytes: []byte("=")},
+		)
+		toks = append(toks, values[i].Tokens()...)
+		toks = append(toks, &hclwrite.Token{Type: hclsyntax.TokenNewline, Bytes: []byte("\n")})
+	}
+	toks = append(toks, &hclwrite.Token{Type: hclsyntax.TokenCBrace, Bytes: []byte("}")})
+	return toks
+}
@@ -0,0 +1,98 @@
+package generator
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"unicode"
+
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/zclconf/go-cty/cty"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+)
+
+const (
+	variablesFileName = "variables.tf"
+	tfvarsFileName    = "terraform.tfvars.json"
+)
+
+// VariableValues accumulates Terraform variables extracted out of
+// generated .tf files, in declaration order, so their real values can be
+// written to a tfvars file instead of being inlined.
+type VariableValues struct {
+	names  []string
+	values map[string]cty.Value
+}
+
+// NewVariableValues returns an empty VariableValues.
+func NewVariableValues() *VariableValues {
+	return &VariableValues{values: map[string]cty.Value{}}
+}
+
+// Add records name as a variable to declare, with value as the real value
+// to place in tfvars. Re-adding the same name overwrites its value without
+// changing declaration order.
+func (v *VariableValues) Add(name string, value cty.Value) {
+	if _, exists := v.values[name]; !exists {
+		v.names = append(v.names, name)
+	}
+	v.values[name] = value
+}
+
+// Write emits dir/variables.tf, declaring each accumulated name as a
+// sensitive variable, and dir/terraform.tfvars.json with the matching
+// values, so the real secret material never lands in the committed .tf
+// files. Writes nothing when no variables were added.
+func (v *VariableValues) Write(dir string) error {
+	if len(v.names) == 0 {
+		return nil
+	}
+	makeDir(dir)
+
+	hclFile := hclwrite.NewEmptyFile()
+	for _, name := range v.names {
+		block := hclFile.Body().AppendNewBlock("variable", []string{name})
+		block.Body().SetAttributeValue("sensitive", cty.True)
+	}
+	createFile(dir, variablesFileName).Write(hclFile.Bytes())
+
+	tfvars := make(map[string]interface{}, len(v.names))
+	for _, name := range v.names {
+		value := v.values[name]
+		raw, err := ctyjson.Marshal(value, value.Type())
+		if err != nil {
+			return fmt.Errorf("marshalling tfvars value for %q: %w", name, err)
+		}
+		var decoded interface{}
+		if err := json.Unmarshal(raw, &decoded); err != nil {
+			return fmt.Errorf("decoding tfvars value for %q: %w", name, err)
+		}
+		tfvars[name] = decoded
+	}
+
+	data, err := json.MarshalIndent(tfvars, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshalling %s: %w", tfvarsFileName, err)
+	}
+	data = append(data, '\n')
+	if err := os.WriteFile(dir+"/"+tfvarsFileName, data, 0600); err != nil {
+		return fmt.Errorf("writing %s: %w", tfvarsFileName, err)
+	}
+	return nil
+}
+
+// sanitizeIdent rewrites s into a valid HCL identifier by replacing any
+// character that isn't a letter, digit or underscore with an underscore.
+// Used to turn arbitrary secret names into Terraform variable names.
+func sanitizeIdent(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		} else {
+			b.WriteRune('_')
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,83 @@
+package hclx
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// Tokens renders t as the hclwrite tokens for an expression, suitable for
+// hclwrite.Body.SetAttributeRaw. Unlike hcl.Traversal, this supports index
+// steps keyed by another traversal (e.g. each.key) and splats.
+func (t Traversal) Tokens() hclwrite.Tokens {
+	var toks hclwrite.Tokens
+	for i, step := range t {
+		switch s := step.(type) {
+		case Root:
+			toks = append(toks, identToken(s.Name))
+		case Attr:
+			toks = append(toks, dotToken(), identToken(s.Name))
+		case Index:
+			toks = append(toks, obrackToken())
+			if s.Ref != nil {
+				toks = append(toks, s.Ref.Tokens()...)
+			} else {
+				toks = append(toks, literalTokens(s.Key)...)
+			}
+			toks = append(toks, cbrackToken())
+		case SplatStep:
+			toks = append(toks, obrackToken(), starToken(), cbrackToken())
+		default:
+			panic(fmt.Sprintf("hclx: unknown step %T at index %d", step, i))
+		}
+	}
+	return toks
+}
+
+func identToken(name string) *hclwrite.Token {
+	return &hclwrite.Token{Type: hclsyntax.TokenIdent, Bytes: []byte(name)}
+}
+
+func dotToken() *hclwrite.Token {
+	return &hclwrite.Token{Type: hclsyntax.TokenDot, Bytes: []byte(".")}
+}
+
+func obrackToken() *hclwrite.Token {
+	return &hclwrite.Token{Type: hclsyntax.TokenOBrack, Bytes: []byte("[")}
+}
+
+func cbrackToken() *hclwrite.Token {
+	return &hclwrite.Token{Type: hclsyntax.TokenCBrack, Bytes: []byte("]")}
+}
+
+func starToken() *hclwrite.Token {
+	return &hclwrite.Token{Type: hclsyntax.TokenStar, Bytes: []byte("*")}
+}
+
+// literalTokens renders a literal index key (string or number) as the
+// tokens hclwrite expects inside a bracketed index, e.g. "a" or 0.
+func literalTokens(v cty.Value) hclwrite.Tokens {
+	switch v.Type() {
+	case cty.String:
+		return hclwrite.Tokens{
+			{Type: hclsyntax.TokenOQuote, Bytes: []byte(`"`)},
+			{Type: hclsyntax.TokenQuotedLit, Bytes: []byte(v.AsString())},
+			{Type: hclsyntax.TokenCQuote, Bytes: []byte(`"`)},
+		}
+	case cty.Number:
+		bf := v.AsBigFloat()
+		return hclwrite.Tokens{
+			{Type: hclsyntax.TokenNumberLit, Bytes: []byte(bf.Text('f', -1))},
+		}
+	default:
+		panic(fmt.Sprintf("hclx: unsupported index key type %s", v.Type().FriendlyName()))
+	}
+}
+
+// SetAttributeTraversal sets name on body to the expression t renders,
+// using raw tokens so index and splat steps round-trip correctly.
+func SetAttributeTraversal(body *hclwrite.Body, name string, t Traversal) {
+	body.SetAttributeRaw(name, t.Tokens())
+}
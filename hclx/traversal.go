@@ -0,0 +1,145 @@
+// Package hclx builds HCL attribute traversals that the hclwrite package
+// cannot express directly: indexed access (local.secrets["a"]) and splats
+// (local.secrets[*].fields). hcl.Traversal only supports literal index
+// keys, so anything with a dynamic key (local.secrets[each.key]) or a
+// splat has to be serialized as raw hclwrite tokens instead.
+package hclx
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// Step is one element of a Traversal: a root name, an attribute access, an
+// index (literal or reference-keyed), or a splat.
+type Step interface {
+	isStep()
+}
+
+// Root is the first step of a traversal, e.g. "local" in local.secrets.
+type Root struct{ Name string }
+
+// Attr accesses a named attribute, e.g. ".secrets".
+type Attr struct{ Name string }
+
+// Index accesses a collection by key, e.g. "[each.key]". Exactly one of
+// Key or Ref is set: Key for a literal string/number index, Ref for an
+// index keyed by another traversal (which can't be expressed as a plain
+// hcl.Traversal).
+type Index struct {
+	Key cty.Value
+	Ref Traversal
+}
+
+// Splat represents the "[*]" step, attribute-splatting over a collection.
+type SplatStep struct{}
+
+func (Root) isStep()      {}
+func (Attr) isStep()      {}
+func (Index) isStep()     {}
+func (SplatStep) isStep() {}
+
+// Traversal is an ordered sequence of Steps, e.g. local.secrets[each.key]
+// is Root{"local"}, Attr{"secrets"}, Index{Ref: Root{"each"},Attr{"key"}}.
+type Traversal []Step
+
+// Splat builds root[*].attrs[0].attrs[1]..., the programmatic equivalent
+// of parsing "root[*].attr0.attr1...".
+func Splat(root Traversal, attrs ...string) Traversal {
+	t := make(Traversal, 0, len(root)+1+len(attrs))
+	t = append(t, root...)
+	t = append(t, SplatStep{})
+	for _, a := range attrs {
+		t = append(t, Attr{Name: a})
+	}
+	return t
+}
+
+// ParseTraversalStr parses s (e.g. "local.secrets[each.key].fields") into
+// a Traversal, supporting index and splat steps that hcl.ParseTraversalAbs
+// rejects.
+func ParseTraversalStr(s string) (Traversal, error) {
+	expr, diags := hclsyntax.ParseExpression([]byte(s), "hclx.ParseTraversalStr", hcl.InitialPos)
+	if diags.HasErrors() {
+		return nil, fmt.Errorf("hclx: parsing %q: %w", s, diags)
+	}
+	return exprToTraversal(expr)
+}
+
+func exprToTraversal(expr hclsyntax.Expression) (Traversal, error) {
+	switch e := expr.(type) {
+	case *hclsyntax.ScopeTraversalExpr:
+		return hclTraversalToSteps(e.Traversal), nil
+
+	case *hclsyntax.RelativeTraversalExpr:
+		base, err := exprToTraversal(e.Source)
+		if err != nil {
+			return nil, err
+		}
+		return append(base, hclTraversalToSteps(e.Traversal)...), nil
+
+	case *hclsyntax.IndexExpr:
+		base, err := exprToTraversal(e.Collection)
+		if err != nil {
+			return nil, err
+		}
+		idx, err := indexStep(e.Key)
+		if err != nil {
+			return nil, err
+		}
+		return append(base, idx), nil
+
+	case *hclsyntax.SplatExpr:
+		base, err := exprToTraversal(e.Source)
+		if err != nil {
+			return nil, err
+		}
+		base = append(base, SplatStep{})
+		each, err := exprToTraversal(e.Each)
+		if err != nil {
+			return nil, err
+		}
+		return append(base, each...), nil
+
+	case *hclsyntax.AnonSymbolExpr:
+		// The implicit "current element" the splat iterates over; it
+		// contributes no steps of its own, only what follows it.
+		return Traversal{}, nil
+
+	default:
+		return nil, fmt.Errorf("hclx: unsupported expression %T in traversal", expr)
+	}
+}
+
+// indexStep builds an Index step for key, a literal value when possible
+// and a nested traversal (e.g. each.key) otherwise.
+func indexStep(key hclsyntax.Expression) (Step, error) {
+	if lit, ok := key.(*hclsyntax.LiteralValueExpr); ok {
+		return Index{Key: lit.Val}, nil
+	}
+	ref, err := exprToTraversal(key)
+	if err != nil {
+		return nil, fmt.Errorf("hclx: unsupported index key: %w", err)
+	}
+	return Index{Ref: ref}, nil
+}
+
+// hclTraversalToSteps converts a plain hcl.Traversal (only roots, attrs and
+// literal indices) into Steps.
+func hclTraversalToSteps(t hcl.Traversal) Traversal {
+	steps := make(Traversal, 0, len(t))
+	for _, part := range t {
+		switch p := part.(type) {
+		case hcl.TraverseRoot:
+			steps = append(steps, Root{Name: p.Name})
+		case hcl.TraverseAttr:
+			steps = append(steps, Attr{Name: p.Name})
+		case hcl.TraverseIndex:
+			steps = append(steps, Index{Key: p.Key})
+		}
+	}
+	return steps
+}
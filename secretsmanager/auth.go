@@ -0,0 +1,85 @@
+package secretsmanager
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// iamTokenURL is IBM Cloud's IAM token exchange endpoint.
+const iamTokenURL = "https://iam.cloud.ibm.com/identity/token"
+
+// iamAuthenticator exchanges an IAM API key for a bearer token and caches it
+// until shortly before it expires.
+type iamAuthenticator struct {
+	apiKey string
+
+	mu        sync.Mutex
+	token     string
+	expiresAt time.Time
+}
+
+func newIAMAuthenticator(apiKey string) *iamAuthenticator {
+	return &iamAuthenticator{apiKey: apiKey}
+}
+
+// Token returns a cached bearer token, refreshing it if it is missing or
+// about to expire.
+func (a *iamAuthenticator) Token() (string, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.token != "" && time.Now().Before(a.expiresAt) {
+		return a.token, nil
+	}
+
+	token, expiresIn, err := requestIAMToken(a.apiKey)
+	if err != nil {
+		return "", err
+	}
+	a.token = token
+	// Refresh a little early to avoid racing the actual expiry.
+	a.expiresAt = time.Now().Add(time.Duration(expiresIn) * time.Second / 2)
+	return a.token, nil
+}
+
+type iamTokenResponse struct {
+	AccessToken string `json:"access_token"`
+	ExpiresIn   int    `json:"expires_in"`
+}
+
+func requestIAMToken(apiKey string) (string, int, error) {
+	form := url.Values{
+		"grant_type": {"urn:ibm:params:oauth:grant-type:apikey"},
+		"apikey":     {apiKey},
+	}
+	req, err := http.NewRequest(http.MethodPost, iamTokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", 0, retryable(fmt.Errorf("building IAM token request: %w", err))
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", 0, retryable(fmt.Errorf("requesting IAM token: %w", err))
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError {
+		return "", 0, retryable(fmt.Errorf("IAM token request failed: %s", resp.Status))
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", 0, fmt.Errorf("IAM token request failed: %s", resp.Status)
+	}
+
+	var body iamTokenResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return "", 0, fmt.Errorf("decoding IAM token response: %w", err)
+	}
+	return body.AccessToken, body.ExpiresIn, nil
+}
@@ -0,0 +1,50 @@
+package secretsmanager
+
+import (
+	"errors"
+	"time"
+)
+
+// baseBackoff is the delay before the first retry; each subsequent retry
+// doubles it.
+const baseBackoff = 250 * time.Millisecond
+
+// retryableError wraps an error that is safe to retry (timeouts, 429s, 5xx).
+// Errors that aren't wrapped in retryableError are treated as permanent.
+type retryableError struct {
+	err error
+}
+
+func (r *retryableError) Error() string { return r.err.Error() }
+func (r *retryableError) Unwrap() error { return r.err }
+
+func retryable(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &retryableError{err: err}
+}
+
+func isRetryable(err error) bool {
+	var re *retryableError
+	return errors.As(err, &re)
+}
+
+// withBackoff calls fn until it succeeds, maxRetries is exhausted, or fn
+// returns a non-retryable error. Delay doubles after each attempt.
+func withBackoff(maxRetries int, fn func() error) error {
+	var err error
+	delay := baseBackoff
+	for attempt := 0; attempt <= maxRetries; attempt++ {
+		err = fn()
+		if err == nil {
+			return nil
+		}
+		if !isRetryable(err) || attempt == maxRetries {
+			break
+		}
+		time.Sleep(delay)
+		delay *= 2
+	}
+	return err
+}
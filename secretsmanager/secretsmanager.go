@@ -0,0 +1,189 @@
+// Package secretsmanager talks to IBM Cloud Secrets Manager and returns the
+// secret inventory used to populate the generated Terraform locals.
+package secretsmanager
+
+import (
+	"fmt"
+	"math/rand"
+	"os"
+)
+
+// Field is a single named value within a secret, along with the CRN that
+// identifies it in IBM Cloud.
+type Field struct {
+	Name string
+	CRN  string
+}
+
+// Secret is a secret group entry: a name plus the set of fields IBM Cloud
+// Secrets Manager returned for it.
+type Secret struct {
+	Name   string
+	Fields []Field
+}
+
+// Config controls how Client authenticates and talks to Secrets Manager.
+type Config struct {
+	// APIKey is the IAM API key used to authenticate. When empty, NewClient
+	// falls back to the IBMCLOUD_API_KEY environment variable and then to
+	// APIKeyFile.
+	APIKey string
+
+	// APIKeyFile is a path to a file containing the IAM API key, used when
+	// APIKey and the environment variable are both unset.
+	APIKeyFile string
+
+	// InstanceURL is the Secrets Manager instance endpoint, e.g.
+	// "https://<instance-id>.<region>.secrets-manager.appdomain.cloud".
+	InstanceURL string
+
+	// DryRun, when true, skips all network calls and returns synthetic
+	// secrets so the generator can be exercised without live credentials.
+	DryRun bool
+
+	// PageSize controls how many secrets are requested per page when
+	// listing. Defaults to 50 when zero.
+	PageSize int
+
+	// MaxRetries bounds how many times a failed request is retried with
+	// exponential backoff before giving up. Defaults to 3 when zero.
+	MaxRetries int
+}
+
+const (
+	defaultPageSize   = 50
+	defaultMaxRetries = 3
+)
+
+// envAPIKey is the environment variable consulted when Config.APIKey is
+// empty.
+const envAPIKey = "IBMCLOUD_API_KEY"
+
+// Client fetches secret groups and metadata from IBM Cloud Secrets Manager.
+type Client struct {
+	cfg        Config
+	apiKey     string
+	authClient *iamAuthenticator
+}
+
+// NewClient builds a Client from cfg, resolving the IAM API key from
+// cfg.APIKey, the IBMCLOUD_API_KEY environment variable, or cfg.APIKeyFile,
+// in that order. In dry-run mode no credentials are required.
+func NewClient(cfg Config) (*Client, error) {
+	if cfg.PageSize <= 0 {
+		cfg.PageSize = defaultPageSize
+	}
+	if cfg.MaxRetries <= 0 {
+		cfg.MaxRetries = defaultMaxRetries
+	}
+
+	if cfg.DryRun {
+		return &Client{cfg: cfg}, nil
+	}
+
+	apiKey, err := resolveAPIKey(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("secretsmanager: %w", err)
+	}
+	if cfg.InstanceURL == "" {
+		return nil, fmt.Errorf("secretsmanager: InstanceURL is required unless DryRun is set")
+	}
+
+	return &Client{
+		cfg:        cfg,
+		apiKey:     apiKey,
+		authClient: newIAMAuthenticator(apiKey),
+	}, nil
+}
+
+func resolveAPIKey(cfg Config) (string, error) {
+	if cfg.APIKey != "" {
+		return cfg.APIKey, nil
+	}
+	if key := os.Getenv(envAPIKey); key != "" {
+		return key, nil
+	}
+	if cfg.APIKeyFile != "" {
+		data, err := os.ReadFile(cfg.APIKeyFile)
+		if err != nil {
+			return "", fmt.Errorf("reading api key file %q: %w", cfg.APIKeyFile, err)
+		}
+		return trimNewline(string(data)), nil
+	}
+	return "", fmt.Errorf("no IAM API key: set Config.APIKey, %s, or Config.APIKeyFile", envAPIKey)
+}
+
+func trimNewline(s string) string {
+	for len(s) > 0 && (s[len(s)-1] == '\n' || s[len(s)-1] == '\r') {
+		s = s[:len(s)-1]
+	}
+	return s
+}
+
+// ListSecrets returns every secret in the configured Secrets Manager
+// instance, transparently paginating and retrying transient failures with
+// backoff. In dry-run mode it returns synthetic data instead of calling out
+// to IBM Cloud.
+func (c *Client) ListSecrets() ([]Secret, error) {
+	if c.cfg.DryRun {
+		return dryRunSecrets(), nil
+	}
+
+	var (
+		secrets []Secret
+		offset  int
+	)
+	for {
+		page, total, err := c.listSecretsPage(offset)
+		if err != nil {
+			return nil, fmt.Errorf("listing secrets at offset %d: %w", offset, err)
+		}
+		secrets = append(secrets, page...)
+		offset += len(page)
+		if len(page) == 0 || offset >= total {
+			break
+		}
+	}
+	return secrets, nil
+}
+
+// listSecretsPage fetches a single page of secrets starting at offset,
+// retrying transient errors with exponential backoff, and returns the page
+// along with the total secret count reported by the API.
+func (c *Client) listSecretsPage(offset int) ([]Secret, int, error) {
+	var (
+		page  []Secret
+		total int
+	)
+	err := withBackoff(c.cfg.MaxRetries, func() error {
+		token, err := c.authClient.Token()
+		if err != nil {
+			return err
+		}
+		resp, err := fetchSecretsPage(c.cfg.InstanceURL, token, offset, c.cfg.PageSize)
+		if err != nil {
+			return err
+		}
+		page, total = resp.secrets, resp.total
+		return nil
+	})
+	return page, total, err
+}
+
+func dryRunSecrets() []Secret {
+	secrets := make([]Secret, 0, 5)
+	for i := 0; i < 5; i++ {
+		fields := make([]Field, 0, 5)
+		for j := 0; j < 5; j++ {
+			fields = append(fields, Field{
+				Name: fmt.Sprintf("field-%d", rand.Int()),
+				CRN:  fmt.Sprintf("crn:v1:bluemix:%d", rand.Int()),
+			})
+		}
+		secrets = append(secrets, Secret{
+			Name:   fmt.Sprintf("secret-%d", i),
+			Fields: fields,
+		})
+	}
+	return secrets
+}
@@ -0,0 +1,70 @@
+package secretsmanager
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// secretsPage is the decoded response for a single page of the Secrets
+// Manager "list secrets" endpoint, reduced to what the generator needs.
+type secretsPage struct {
+	secrets []Secret
+	total   int
+}
+
+// secretsManagerListResponse mirrors the subset of the IBM Cloud Secrets
+// Manager v2 "list secrets" response body that callers need.
+type secretsManagerListResponse struct {
+	TotalCount int `json:"total_count"`
+	Secrets    []struct {
+		Name       string `json:"name"`
+		SecretID   string `json:"id"`
+		SecretType string `json:"secret_type"`
+		CRN        string `json:"crn"`
+		Fields     []struct {
+			Name string `json:"name"`
+			CRN  string `json:"crn"`
+		} `json:"fields"`
+	} `json:"secrets"`
+}
+
+// fetchSecretsPage requests one page of secrets, starting at offset, from
+// the Secrets Manager instance at instanceURL.
+func fetchSecretsPage(instanceURL, token string, offset, pageSize int) (secretsPage, error) {
+	url := fmt.Sprintf("%s/api/v2/secrets?offset=%d&limit=%d", instanceURL, offset, pageSize)
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return secretsPage{}, retryable(fmt.Errorf("building secrets request: %w", err))
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return secretsPage{}, retryable(fmt.Errorf("fetching secrets: %w", err))
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError {
+		return secretsPage{}, retryable(fmt.Errorf("secrets manager returned %s", resp.Status))
+	}
+	if resp.StatusCode != http.StatusOK {
+		return secretsPage{}, fmt.Errorf("secrets manager returned %s", resp.Status)
+	}
+
+	var body secretsManagerListResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return secretsPage{}, fmt.Errorf("decoding secrets response: %w", err)
+	}
+
+	page := secretsPage{total: body.TotalCount}
+	for _, s := range body.Secrets {
+		fields := make([]Field, 0, len(s.Fields))
+		for _, f := range s.Fields {
+			fields = append(fields, Field{Name: f.Name, CRN: f.CRN})
+		}
+		page.secrets = append(page.secrets, Secret{Name: s.Name, Fields: fields})
+	}
+	return page, nil
+}